@@ -0,0 +1,63 @@
+package chaincode
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// adminAttribute is the client identity attribute that gates admin-only
+// operations such as InitLedger and DeleteAsset.
+const adminAttribute = "asset.admin"
+
+// transferAttribute is the client identity attribute that lets a caller
+// transfer an asset it does not itself own.
+const transferAttribute = "asset.transfer"
+
+// getSubmittingClientIdentity returns the x509 common name and MSP ID of
+// the identity that submitted the current transaction, so that ownership
+// can be tied to a verifiable client identity instead of a caller-supplied
+// string.
+func getSubmittingClientIdentity(ctx contractapi.TransactionContextInterface) (cn string, mspID string, err error) {
+	mspID, err = ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get client MSP ID: %v", err)
+	}
+
+	cert, err := cid.GetX509Certificate(ctx.GetStub())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get client X509 certificate: %v", err)
+	}
+
+	return cert.Subject.CommonName, mspID, nil
+}
+
+// authorizeOwner returns an error unless the submitting client is the
+// recorded owner of asset, or holds the asset.transfer attribute.
+func authorizeOwner(ctx contractapi.TransactionContextInterface, asset *Asset) error {
+	cn, mspID, err := getSubmittingClientIdentity(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cn == asset.Owner && mspID == asset.OwnerOrg {
+		return nil
+	}
+
+	if err := ctx.GetClientIdentity().AssertAttributeValue(transferAttribute, "true"); err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%w: identity %s@%s may not transfer asset %s", ErrUnauthorized, cn, mspID, asset.ID)
+}
+
+// authorizeAdmin returns an error unless the submitting client holds the
+// asset.admin attribute.
+func authorizeAdmin(ctx contractapi.TransactionContextInterface) error {
+	if err := ctx.GetClientIdentity().AssertAttributeValue(adminAttribute, "true"); err != nil {
+		return fmt.Errorf("%w: submitting client lacks the %s attribute", ErrUnauthorized, adminAttribute)
+	}
+
+	return nil
+}
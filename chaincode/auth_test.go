@@ -0,0 +1,158 @@
+package chaincode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartkjh/chaincode-example/chaincode/mocks"
+)
+
+func newAuthedContext(t *testing.T, mspID, commonName string, attributes map[string]string) *mocks.TransactionContext {
+	t.Helper()
+
+	return withIdentity(t, mocks.NewChaincodeStub(), mspID, commonName, attributes)
+}
+
+// withIdentity returns a transaction context backed by the same world state
+// as stub but submitted by a different, independently-verifiable client
+// identity, so tests can have two distinct callers operate on one ledger.
+func withIdentity(t *testing.T, stub *mocks.ChaincodeStub, mspID, commonName string, attributes map[string]string) *mocks.TransactionContext {
+	t.Helper()
+
+	creator, err := mocks.NewCreator(mspID, commonName)
+	if err != nil {
+		t.Fatalf("failed to build creator: %v", err)
+	}
+
+	identityStub := mocks.NewChaincodeStub()
+	identityStub.State = stub.State
+	identityStub.PrivateState = stub.PrivateState
+	identityStub.Events = stub.Events
+	identityStub.Creator = creator
+
+	return &mocks.TransactionContext{
+		Stub:           identityStub,
+		ClientIdentity: &mocks.ClientIdentity{MSPID: mspID, Attributes: attributes},
+	}
+}
+
+func TestCreateAssetOwnerIsDerivedFromClientIdentity(t *testing.T) {
+	ctx := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	request := `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`
+	if _, err := contract.CreateAsset(ctx, request); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+
+	asset, err := contract.ReadAsset(ctx, `{"asset_id":"asset1"}`)
+	if err != nil {
+		t.Fatalf("ReadAsset returned error: %v", err)
+	}
+	if asset.Owner != "Tomoko" || asset.OwnerOrg != "Org1MSP" {
+		t.Fatalf("asset owner = %s@%s, want Tomoko@Org1MSP", asset.Owner, asset.OwnerOrg)
+	}
+}
+
+func TestUpdateAssetRequiresOwnership(t *testing.T) {
+	owner := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(owner, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+
+	stranger := withIdentity(t, owner.Stub, "Org2MSP", "Eve", nil)
+	_, err := contract.UpdateAsset(stranger, `{"asset_id":"asset1","color":"red","size":6,"appraisedValue":400}`)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("UpdateAsset by non-owner returned %v, want ErrUnauthorized", err)
+	}
+
+	if _, err := contract.UpdateAsset(owner, `{"asset_id":"asset1","color":"red","size":6,"appraisedValue":400}`); err != nil {
+		t.Fatalf("UpdateAsset by owner returned error: %v", err)
+	}
+
+	asset, err := contract.ReadAsset(owner, `{"asset_id":"asset1"}`)
+	if err != nil {
+		t.Fatalf("ReadAsset returned error: %v", err)
+	}
+	if asset.Owner != "Tomoko" || asset.OwnerOrg != "Org1MSP" {
+		t.Fatalf("UpdateAsset changed ownership to %s@%s, want it unchanged", asset.Owner, asset.OwnerOrg)
+	}
+}
+
+func TestTransferAssetAllowsTransferAttributeHolder(t *testing.T) {
+	owner := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(owner, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+
+	broker := withIdentity(t, owner.Stub, "Org2MSP", "Carol", map[string]string{transferAttribute: "true"})
+	_, err := contract.TransferAsset(broker, `{"asset_id":"asset1","owner":"Brad","ownerOrg":"Org2MSP"}`)
+	if err != nil {
+		t.Fatalf("TransferAsset by transfer-attribute holder returned error: %v", err)
+	}
+
+	asset, err := contract.ReadAsset(owner, `{"asset_id":"asset1"}`)
+	if err != nil {
+		t.Fatalf("ReadAsset returned error: %v", err)
+	}
+	if asset.Owner != "Brad" || asset.OwnerOrg != "Org2MSP" {
+		t.Fatalf("asset owner = %s@%s, want Brad@Org2MSP", asset.Owner, asset.OwnerOrg)
+	}
+}
+
+// TestInitLedgerSeedsTransferableAssets guards against InitLedger seeding
+// assets with OwnerOrg left at its zero value: since authorizeOwner
+// requires mspID == asset.OwnerOrg, an empty OwnerOrg would permanently
+// lock every seeded asset out of TransferAsset/UpdateAsset.
+func TestInitLedgerSeedsTransferableAssets(t *testing.T) {
+	admin := newAuthedContext(t, "Org1MSP", "Tomoko", map[string]string{adminAttribute: "true"})
+
+	var contract SmartContract
+	if err := contract.InitLedger(admin); err != nil {
+		t.Fatalf("InitLedger returned error: %v", err)
+	}
+
+	asset, err := contract.ReadAsset(admin, `{"asset_id":"asset1"}`)
+	if err != nil {
+		t.Fatalf("ReadAsset returned error: %v", err)
+	}
+	if asset.OwnerOrg != "Org1MSP" {
+		t.Fatalf("seeded asset OwnerOrg = %q, want %q", asset.OwnerOrg, "Org1MSP")
+	}
+
+	// admin's identity (cn=Tomoko, mspID=Org1MSP) matches asset1's seeded
+	// Owner/OwnerOrg directly, with no asset.transfer escape hatch needed.
+	if _, err := contract.TransferAsset(admin, `{"asset_id":"asset1","owner":"Brad","ownerOrg":"Org2MSP"}`); err != nil {
+		t.Fatalf("TransferAsset of a seeded asset returned error: %v", err)
+	}
+
+	// asset2 is still seeded as Brad@Org1MSP; an identity with that same
+	// cn/mspID should be able to update it through the ownership path too.
+	brad := withIdentity(t, admin.Stub, "Org1MSP", "Brad", nil)
+	if _, err := contract.UpdateAsset(brad, `{"asset_id":"asset2","color":"indigo","size":6,"appraisedValue":450}`); err != nil {
+		t.Fatalf("UpdateAsset of a seeded asset returned error: %v", err)
+	}
+}
+
+func TestDeleteAssetRequiresAdminAttribute(t *testing.T) {
+	owner := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(owner, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+
+	_, err := contract.DeleteAsset(owner, `{"asset_id":"asset1"}`)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("DeleteAsset without admin attribute returned %v, want ErrUnauthorized", err)
+	}
+
+	admin := withIdentity(t, owner.Stub, "Org1MSP", "Tomoko", map[string]string{adminAttribute: "true"})
+	if _, err := contract.DeleteAsset(admin, `{"asset_id":"asset1"}`); err != nil {
+		t.Fatalf("DeleteAsset by admin returned error: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package chaincode
+
+import "fmt"
+
+// ErrorCode is a stable, numeric identifier for a class of chaincode
+// error, so that SDK clients can branch on errors.Is / errors.As instead
+// of matching on error message text.
+type ErrorCode int
+
+const (
+	// ErrCodeInvalidInput marks a request that failed validation.
+	ErrCodeInvalidInput ErrorCode = iota + 1
+	// ErrCodeAssetExists marks an attempt to create an asset that is
+	// already present in the world state.
+	ErrCodeAssetExists
+	// ErrCodeAssetNotFound marks a reference to an asset that does not
+	// exist in the world state.
+	ErrCodeAssetNotFound
+	// ErrCodeUnauthorized marks a caller that is not authorized to
+	// perform the requested operation.
+	ErrCodeUnauthorized
+)
+
+// ChaincodeError is a typed error carrying a stable ErrorCode alongside a
+// human-readable message.
+type ChaincodeError struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e *ChaincodeError) Error() string {
+	return fmt.Sprintf("[E%d] %s", e.Code, e.Message)
+}
+
+// Sentinel errors for use with errors.Is / errors.As and fmt.Errorf's %w.
+var (
+	ErrInvalidInput  = &ChaincodeError{Code: ErrCodeInvalidInput, Message: "invalid input"}
+	ErrAssetExists   = &ChaincodeError{Code: ErrCodeAssetExists, Message: "asset already exists"}
+	ErrAssetNotFound = &ChaincodeError{Code: ErrCodeAssetNotFound, Message: "asset not found"}
+	ErrUnauthorized  = &ChaincodeError{Code: ErrCodeUnauthorized, Message: "not authorized"}
+)
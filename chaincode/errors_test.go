@@ -0,0 +1,77 @@
+package chaincode
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/smartkjh/chaincode-example/chaincode/mocks"
+)
+
+func TestCreateAssetReturnsTypedErrors(t *testing.T) {
+	ctx := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+
+	if _, err := contract.CreateAsset(ctx, `not json`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("CreateAsset with malformed JSON returned %v, want ErrInvalidInput", err)
+	}
+
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset 1","color":"blue","size":5,"appraisedValue":300}`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("CreateAsset with invalid asset id returned %v, want ErrInvalidInput", err)
+	}
+
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); !errors.Is(err, ErrAssetExists) {
+		t.Fatalf("CreateAsset of a duplicate asset returned %v, want ErrAssetExists", err)
+	}
+}
+
+func TestReadAssetReturnsAssetNotFound(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: mocks.NewChaincodeStub()}
+
+	var contract SmartContract
+	if _, err := contract.ReadAsset(ctx, `{"asset_id":"missing"}`); !errors.Is(err, ErrAssetNotFound) {
+		t.Fatalf("ReadAsset of a missing asset returned %v, want ErrAssetNotFound", err)
+	}
+}
+
+func TestGetAssetHistoryReturnsTypedErrors(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: mocks.NewChaincodeStub()}
+
+	var contract SmartContract
+	if _, err := contract.GetAssetHistory(ctx, `not json`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("GetAssetHistory with malformed JSON returned %v, want ErrInvalidInput", err)
+	}
+	if _, err := contract.GetAssetHistory(ctx, `{"asset_id":"asset 1"}`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("GetAssetHistory with invalid asset id returned %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestGetAssetAtTimeReturnsTypedErrors(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: mocks.NewChaincodeStub()}
+
+	var contract SmartContract
+	if _, err := contract.GetAssetAtTime(ctx, `{"asset_id":"asset1","timestamp":"not-a-timestamp"}`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("GetAssetAtTime with invalid timestamp returned %v, want ErrInvalidInput", err)
+	}
+	if _, err := contract.GetAssetAtTime(ctx, `{"asset_id":"asset1","timestamp":"2024-01-01T00:00:00Z"}`); !errors.Is(err, ErrAssetNotFound) {
+		t.Fatalf("GetAssetAtTime with no history returned %v, want ErrAssetNotFound", err)
+	}
+}
+
+func TestDeleteAssetAndTransferAssetValidateAssetID(t *testing.T) {
+	admin := newAuthedContext(t, "Org1MSP", "Tomoko", map[string]string{adminAttribute: "true"})
+
+	var contract SmartContract
+	if _, err := contract.DeleteAsset(admin, `{"asset_id":"asset 1"}`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("DeleteAsset with invalid asset id returned %v, want ErrInvalidInput", err)
+	}
+
+	owner := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+	if _, err := contract.TransferAsset(owner, `{"asset_id":"asset 1","owner":"Brad","ownerOrg":"Org2MSP"}`); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("TransferAsset with invalid asset id returned %v, want ErrInvalidInput", err)
+	}
+}
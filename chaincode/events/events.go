@@ -0,0 +1,34 @@
+// Package events defines the chaincode event names and payload schemas
+// emitted for asset lifecycle changes, so that SDK clients can subscribe
+// to them deterministically instead of parsing free-form log output.
+package events
+
+// Event names passed to ctx.GetStub().SetEvent.
+const (
+	AssetCreated     = "AssetCreated"
+	AssetUpdated     = "AssetUpdated"
+	AssetDeleted     = "AssetDeleted"
+	AssetTransferred = "AssetTransferred"
+)
+
+// AssetCreatedPayload is emitted when CreateAsset succeeds.
+type AssetCreatedPayload struct {
+	ID string `json:"id"`
+}
+
+// AssetUpdatedPayload is emitted when UpdateAsset succeeds.
+type AssetUpdatedPayload struct {
+	ID string `json:"id"`
+}
+
+// AssetDeletedPayload is emitted when DeleteAsset succeeds.
+type AssetDeletedPayload struct {
+	ID string `json:"id"`
+}
+
+// AssetTransferredPayload is emitted when TransferAsset succeeds.
+type AssetTransferredPayload struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
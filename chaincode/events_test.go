@@ -0,0 +1,91 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/smartkjh/chaincode-example/chaincode/mocks"
+
+	"github.com/smartkjh/chaincode-example/chaincode/events"
+)
+
+func TestCreateAssetEmitsAssetCreated(t *testing.T) {
+	ctx := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+
+	var payload events.AssetCreatedPayload
+	assertEvent(t, ctx.Stub, events.AssetCreated, &payload)
+	if payload.ID != "asset1" {
+		t.Fatalf("AssetCreatedPayload.ID = %q, want %q", payload.ID, "asset1")
+	}
+}
+
+func TestUpdateAssetEmitsAssetUpdated(t *testing.T) {
+	ctx := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+	if _, err := contract.UpdateAsset(ctx, `{"asset_id":"asset1","color":"red","size":6,"appraisedValue":400}`); err != nil {
+		t.Fatalf("UpdateAsset returned error: %v", err)
+	}
+
+	var payload events.AssetUpdatedPayload
+	assertEvent(t, ctx.Stub, events.AssetUpdated, &payload)
+	if payload.ID != "asset1" {
+		t.Fatalf("AssetUpdatedPayload.ID = %q, want %q", payload.ID, "asset1")
+	}
+}
+
+func TestDeleteAssetEmitsAssetDeleted(t *testing.T) {
+	ctx := newAuthedContext(t, "Org1MSP", "Tomoko", map[string]string{adminAttribute: "true"})
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+	if _, err := contract.DeleteAsset(ctx, `{"asset_id":"asset1"}`); err != nil {
+		t.Fatalf("DeleteAsset returned error: %v", err)
+	}
+
+	var payload events.AssetDeletedPayload
+	assertEvent(t, ctx.Stub, events.AssetDeleted, &payload)
+	if payload.ID != "asset1" {
+		t.Fatalf("AssetDeletedPayload.ID = %q, want %q", payload.ID, "asset1")
+	}
+}
+
+func TestTransferAssetEmitsAssetTransferred(t *testing.T) {
+	ctx := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+	if _, err := contract.CreateAsset(ctx, `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`); err != nil {
+		t.Fatalf("CreateAsset returned error: %v", err)
+	}
+	if _, err := contract.TransferAsset(ctx, `{"asset_id":"asset1","owner":"Brad","ownerOrg":"Org2MSP"}`); err != nil {
+		t.Fatalf("TransferAsset returned error: %v", err)
+	}
+
+	var payload events.AssetTransferredPayload
+	assertEvent(t, ctx.Stub, events.AssetTransferred, &payload)
+	if payload.ID != "asset1" || payload.From != "Tomoko" || payload.To != "Brad" {
+		t.Fatalf("AssetTransferredPayload = %+v, want ID=asset1 From=Tomoko To=Brad", payload)
+	}
+}
+
+func assertEvent(t *testing.T, stub *mocks.ChaincodeStub, name string, payload interface{}) {
+	t.Helper()
+
+	raw, ok := stub.Events[name]
+	if !ok {
+		t.Fatalf("no event named %q was set; got events %v", name, stub.Events)
+	}
+	if err := json.Unmarshal(raw, payload); err != nil {
+		t.Fatalf("failed to unmarshal %q payload: %v", name, err)
+	}
+}
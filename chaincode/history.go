@@ -0,0 +1,117 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// AssetHistoryEntry describes a single modification of an asset as recorded
+// on the blockchain, as returned by GetHistoryForKey.
+type AssetHistoryEntry struct {
+	TxId      string    `json:"txId"`
+	Timestamp time.Time `json:"timestamp"`
+	IsDelete  bool      `json:"isDelete"`
+	Value     *Asset    `json:"value,omitempty"`
+}
+
+// GetAssetHistory returns the full, ordered (newest first) modification
+// history of the asset identified in rawReadRequest, giving clients an
+// auditable provenance view without changing how assets are stored.
+func (s *SmartContract) GetAssetHistory(ctx contractapi.TransactionContextInterface, rawReadRequest string) ([]*AssetHistoryEntry, error) {
+	var readRequest AssetHistoryRequest
+	if err := json.Unmarshal([]byte(rawReadRequest), &readRequest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(readRequest); err != nil {
+		return nil, err
+	}
+	if err := validateAssetID(readRequest.ID); err != nil {
+		return nil, err
+	}
+
+	historyIterator, err := ctx.GetStub().GetHistoryForKey(readRequest.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history for asset %s: %v", readRequest.ID, err)
+	}
+	defer historyIterator.Close()
+
+	var history []*AssetHistoryEntry
+	for historyIterator.HasNext() {
+		modification, err := historyIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		entry := &AssetHistoryEntry{
+			TxId:      modification.TxId,
+			Timestamp: time.Unix(modification.Timestamp.Seconds, int64(modification.Timestamp.Nanos)),
+			IsDelete:  modification.IsDelete,
+		}
+
+		if !modification.IsDelete {
+			var asset Asset
+			if err := json.Unmarshal(modification.Value, &asset); err != nil {
+				return nil, err
+			}
+			entry.Value = &asset
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
+
+// GetAssetAtTime returns the state of an asset as of the caller-supplied
+// RFC3339 timestamp, found by walking GetHistoryForKey for the most recent
+// modification at or before that time.
+func (s *SmartContract) GetAssetAtTime(ctx contractapi.TransactionContextInterface, rawReq string) (*Asset, error) {
+	var request AssetAtTimeRequest
+	if err := json.Unmarshal([]byte(rawReq), &request); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+	if err := validateAssetID(request.ID); err != nil {
+		return nil, err
+	}
+
+	at, err := time.Parse(time.RFC3339, request.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid RFC3339 timestamp %q: %v", ErrInvalidInput, request.Timestamp, err)
+	}
+
+	historyIterator, err := ctx.GetStub().GetHistoryForKey(request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history for asset %s: %v", request.ID, err)
+	}
+	defer historyIterator.Close()
+
+	for historyIterator.HasNext() {
+		modification, err := historyIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		modTime := time.Unix(modification.Timestamp.Seconds, int64(modification.Timestamp.Nanos))
+		if modTime.After(at) {
+			continue
+		}
+
+		if modification.IsDelete {
+			return nil, fmt.Errorf("%w: asset %s did not exist at %s", ErrAssetNotFound, request.ID, request.Timestamp)
+		}
+
+		var asset Asset
+		if err := json.Unmarshal(modification.Value, &asset); err != nil {
+			return nil, err
+		}
+		return &asset, nil
+	}
+
+	return nil, fmt.Errorf("%w: asset %s did not exist at %s", ErrAssetNotFound, request.ID, request.Timestamp)
+}
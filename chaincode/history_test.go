@@ -0,0 +1,114 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+
+	"github.com/smartkjh/chaincode-example/chaincode/mocks"
+)
+
+var (
+	historyCreatedAt = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	historyUpdatedAt = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	historyDeletedAt = time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+)
+
+func protoTimestamp(t time.Time) *timestamp.Timestamp {
+	return &timestamp.Timestamp{Seconds: t.Unix()}
+}
+
+func newAssetHistory(t *testing.T) *mocks.ChaincodeStub {
+	t.Helper()
+
+	created, err := json.Marshal(Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Tomoko", OwnerOrg: "Org1MSP", AppraisedValue: 300})
+	if err != nil {
+		t.Fatalf("failed to marshal created asset: %v", err)
+	}
+	updated, err := json.Marshal(Asset{ID: "asset1", Color: "red", Size: 6, Owner: "Tomoko", OwnerOrg: "Org1MSP", AppraisedValue: 400})
+	if err != nil {
+		t.Fatalf("failed to marshal updated asset: %v", err)
+	}
+
+	stub := mocks.NewChaincodeStub()
+	// GetHistoryForKey returns modifications newest first.
+	stub.HistoryRecords = []*queryresult.KeyModification{
+		{TxId: "tx3", Timestamp: protoTimestamp(historyDeletedAt), IsDelete: true},
+		{TxId: "tx2", Timestamp: protoTimestamp(historyUpdatedAt), IsDelete: false, Value: updated},
+		{TxId: "tx1", Timestamp: protoTimestamp(historyCreatedAt), IsDelete: false, Value: created},
+	}
+
+	return stub
+}
+
+func TestGetAssetHistoryReturnsNewestFirst(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: newAssetHistory(t)}
+
+	var contract SmartContract
+	history, err := contract.GetAssetHistory(ctx, `{"asset_id":"asset1"}`)
+	if err != nil {
+		t.Fatalf("GetAssetHistory returned error: %v", err)
+	}
+
+	if len(history) != 3 {
+		t.Fatalf("GetAssetHistory returned %d entries, want 3", len(history))
+	}
+
+	if !history[0].IsDelete || history[0].TxId != "tx3" || history[0].Value != nil {
+		t.Fatalf("history[0] = %+v, want the delete tombstone with a nil value", history[0])
+	}
+	if history[1].IsDelete || history[1].TxId != "tx2" || history[1].Value == nil || history[1].Value.Color != "red" {
+		t.Fatalf("history[1] = %+v, want the update with color=red", history[1])
+	}
+	if history[2].IsDelete || history[2].TxId != "tx1" || history[2].Value == nil || history[2].Value.Color != "blue" {
+		t.Fatalf("history[2] = %+v, want the create with color=blue", history[2])
+	}
+}
+
+func TestGetAssetAtTimeResolvesMostRecentModificationAtOrBefore(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: newAssetHistory(t)}
+
+	var contract SmartContract
+
+	between := historyCreatedAt.Add(12 * time.Hour).Format(time.RFC3339)
+	asset, err := contract.GetAssetAtTime(ctx, `{"asset_id":"asset1","timestamp":"`+between+`"}`)
+	if err != nil {
+		t.Fatalf("GetAssetAtTime(between create and update) returned error: %v", err)
+	}
+	if asset.Color != "blue" {
+		t.Fatalf("GetAssetAtTime(between create and update) = %+v, want color=blue", asset)
+	}
+
+	atUpdate := historyUpdatedAt.Format(time.RFC3339)
+	asset, err = contract.GetAssetAtTime(ctx, `{"asset_id":"asset1","timestamp":"`+atUpdate+`"}`)
+	if err != nil {
+		t.Fatalf("GetAssetAtTime(at update) returned error: %v", err)
+	}
+	if asset.Color != "red" {
+		t.Fatalf("GetAssetAtTime(at update) = %+v, want color=red", asset)
+	}
+}
+
+func TestGetAssetAtTimeAfterDeleteReturnsAssetNotFound(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: newAssetHistory(t)}
+
+	var contract SmartContract
+	after := historyDeletedAt.Add(time.Hour).Format(time.RFC3339)
+	if _, err := contract.GetAssetAtTime(ctx, `{"asset_id":"asset1","timestamp":"`+after+`"}`); !errors.Is(err, ErrAssetNotFound) {
+		t.Fatalf("GetAssetAtTime(after delete) returned %v, want ErrAssetNotFound", err)
+	}
+}
+
+func TestGetAssetAtTimeBeforeCreationReturnsAssetNotFound(t *testing.T) {
+	ctx := &mocks.TransactionContext{Stub: newAssetHistory(t)}
+
+	var contract SmartContract
+	before := historyCreatedAt.Add(-time.Hour).Format(time.RFC3339)
+	if _, err := contract.GetAssetAtTime(ctx, `{"asset_id":"asset1","timestamp":"`+before+`"}`); !errors.Is(err, ErrAssetNotFound) {
+		t.Fatalf("GetAssetAtTime(before creation) returned %v, want ErrAssetNotFound", err)
+	}
+}
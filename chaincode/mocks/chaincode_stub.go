@@ -0,0 +1,159 @@
+// Package mocks provides lightweight, hand-written stand-ins for the
+// fabric-chaincode-go and fabric-contract-api-go interfaces, covering only
+// the methods this chaincode's unit tests exercise.
+package mocks
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"github.com/hyperledger/fabric-protos-go/peer"
+)
+
+// ChaincodeStub is an in-memory shim.ChaincodeStubInterface. Any method not
+// overridden here is satisfied by the embedded nil interface and will
+// panic if called, which is the signal to extend this mock.
+type ChaincodeStub struct {
+	shim.ChaincodeStubInterface
+
+	State        map[string][]byte
+	PrivateState map[string]map[string][]byte
+	Transient    map[string][]byte
+	Events       map[string][]byte
+	Creator      []byte
+
+	HistoryRecords []*queryresult.KeyModification
+	QueryRecords   []*queryresult.KV
+	QueryBookmark  string
+	LastQuery      string
+}
+
+// NewChaincodeStub returns a ChaincodeStub with all backing maps
+// initialized.
+func NewChaincodeStub() *ChaincodeStub {
+	return &ChaincodeStub{
+		State:        make(map[string][]byte),
+		PrivateState: make(map[string]map[string][]byte),
+		Transient:    make(map[string][]byte),
+		Events:       make(map[string][]byte),
+	}
+}
+
+func (s *ChaincodeStub) GetState(key string) ([]byte, error) {
+	return s.State[key], nil
+}
+
+func (s *ChaincodeStub) PutState(key string, value []byte) error {
+	s.State[key] = value
+	return nil
+}
+
+func (s *ChaincodeStub) DelState(key string) error {
+	delete(s.State, key)
+	return nil
+}
+
+func (s *ChaincodeStub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	var records []*queryresult.KV
+	for key, value := range s.State {
+		records = append(records, &queryresult.KV{Key: key, Value: value})
+	}
+	return &StateQueryIterator{Records: records}, nil
+}
+
+func (s *ChaincodeStub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	s.LastQuery = query
+	return &StateQueryIterator{Records: s.QueryRecords}, nil
+}
+
+func (s *ChaincodeStub) GetQueryResultWithPagination(query string, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	metadata := &peer.QueryResponseMetadata{
+		FetchedRecordsCount: int32(len(s.QueryRecords)),
+		Bookmark:            s.QueryBookmark,
+	}
+	return &StateQueryIterator{Records: s.QueryRecords}, metadata, nil
+}
+
+func (s *ChaincodeStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	return &HistoryQueryIterator{Records: s.HistoryRecords}, nil
+}
+
+func (s *ChaincodeStub) GetPrivateData(collection, key string) ([]byte, error) {
+	return s.PrivateState[collection][key], nil
+}
+
+func (s *ChaincodeStub) PutPrivateData(collection, key string, value []byte) error {
+	if s.PrivateState[collection] == nil {
+		s.PrivateState[collection] = make(map[string][]byte)
+	}
+	s.PrivateState[collection][key] = value
+	return nil
+}
+
+func (s *ChaincodeStub) DelPrivateData(collection, key string) error {
+	delete(s.PrivateState[collection], key)
+	return nil
+}
+
+func (s *ChaincodeStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value, ok := s.PrivateState[collection][key]
+	if !ok {
+		return nil, nil
+	}
+	hash := sha256.Sum256(value)
+	return hash[:], nil
+}
+
+func (s *ChaincodeStub) GetTransient() (map[string][]byte, error) {
+	return s.Transient, nil
+}
+
+func (s *ChaincodeStub) SetEvent(name string, payload []byte) error {
+	s.Events[name] = payload
+	return nil
+}
+
+func (s *ChaincodeStub) GetCreator() ([]byte, error) {
+	return s.Creator, nil
+}
+
+// StateQueryIterator is an in-memory shim.StateQueryIteratorInterface.
+type StateQueryIterator struct {
+	Records []*queryresult.KV
+	pos     int
+}
+
+func (it *StateQueryIterator) HasNext() bool {
+	return it.pos < len(it.Records)
+}
+
+func (it *StateQueryIterator) Next() (*queryresult.KV, error) {
+	record := it.Records[it.pos]
+	it.pos++
+	return record, nil
+}
+
+func (it *StateQueryIterator) Close() error {
+	return nil
+}
+
+// HistoryQueryIterator is an in-memory shim.HistoryQueryIteratorInterface.
+type HistoryQueryIterator struct {
+	Records []*queryresult.KeyModification
+	pos     int
+}
+
+func (it *HistoryQueryIterator) HasNext() bool {
+	return it.pos < len(it.Records)
+}
+
+func (it *HistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	record := it.Records[it.pos]
+	it.pos++
+	return record, nil
+}
+
+func (it *HistoryQueryIterator) Close() error {
+	return nil
+}
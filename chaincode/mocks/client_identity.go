@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"crypto/x509"
+	"fmt"
+)
+
+// ClientIdentity is an in-memory cid.ClientIdentity for use in unit tests.
+type ClientIdentity struct {
+	MSPID      string
+	Cert       *x509.Certificate
+	Attributes map[string]string
+}
+
+func (c *ClientIdentity) GetID() (string, error) {
+	return "", nil
+}
+
+func (c *ClientIdentity) GetMSPID() (string, error) {
+	return c.MSPID, nil
+}
+
+func (c *ClientIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	value, ok := c.Attributes[attrName]
+	return value, ok, nil
+}
+
+func (c *ClientIdentity) AssertAttributeValue(attrName, attrValue string) error {
+	value, ok := c.Attributes[attrName]
+	if !ok || value != attrValue {
+		return fmt.Errorf("attribute %s does not have value %s", attrName, attrValue)
+	}
+	return nil
+}
+
+func (c *ClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return c.Cert, nil
+}
@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// TransactionContext is an in-memory contractapi.TransactionContextInterface
+// for use in unit tests.
+type TransactionContext struct {
+	contractapi.TransactionContextInterface
+
+	Stub           *ChaincodeStub
+	ClientIdentity *ClientIdentity
+}
+
+func (ctx *TransactionContext) GetStub() shim.ChaincodeStubInterface {
+	return ctx.Stub
+}
+
+func (ctx *TransactionContext) GetClientIdentity() cid.ClientIdentity {
+	return ctx.ClientIdentity
+}
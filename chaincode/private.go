@@ -0,0 +1,269 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// assetCollection is the shared private data collection, readable by every
+// org named in collections_config.json, that holds the appraised value of
+// each privately-created asset.
+const assetCollection = "assetCollection"
+
+// AssetPrivateDetails holds the fields of an asset that must not appear on
+// the world state: the appraised value set at creation and, once a sale is
+// agreed, the negotiated sale price.
+type AssetPrivateDetails struct {
+	ID             string `json:"asset_id"`
+	AppraisedValue int    `json:"appraisedValue"`
+	SalePrice      int    `json:"salePrice"`
+}
+
+// privateDetailsCollection returns the name of the per-org collection that
+// holds an org's privately-agreed transfer price for an asset.
+func privateDetailsCollection(orgMSPID string) string {
+	return orgMSPID + "PrivateDetails"
+}
+
+// CreateAssetPrivate issues a new asset using data supplied via the
+// transient map (key "asset_properties") instead of function arguments, so
+// that the appraised value never appears in the transaction proposal or on
+// the public ledger. The public fields are written to the world state as
+// usual; AppraisedValue is written to assetCollection.
+func (s *SmartContract) CreateAssetPrivate(ctx contractapi.TransactionContextInterface) (string, error) {
+	transientMap, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return "", fmt.Errorf("failed to read transient map: %v", err)
+	}
+
+	transientJSON, ok := transientMap["asset_properties"]
+	if !ok {
+		return "", fmt.Errorf("%w: asset_properties key not found in the transient map", ErrInvalidInput)
+	}
+
+	var input CreateAssetPrivateRequest
+	if err := json.Unmarshal(transientJSON, &input); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(input); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(input.ID); err != nil {
+		return "", err
+	}
+
+	exists, err := s.AssetExists(ctx, input.ID)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", fmt.Errorf("%w: asset %s", ErrAssetExists, input.ID)
+	}
+
+	cn, mspID, err := getSubmittingClientIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	asset := Asset{ID: input.ID, Color: input.Color, Size: input.Size, Owner: cn, OwnerOrg: mspID}
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return "", fmt.Errorf("failed json.Marshal: %v", err)
+	}
+	if err := ctx.GetStub().PutState(asset.ID, assetJSON); err != nil {
+		return "", fmt.Errorf("failed to put asset to world state: %v", err)
+	}
+
+	details := AssetPrivateDetails{ID: input.ID, AppraisedValue: input.AppraisedValue}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return "", fmt.Errorf("failed json.Marshal: %v", err)
+	}
+	if err := ctx.GetStub().PutPrivateData(assetCollection, input.ID, detailsJSON); err != nil {
+		return "", fmt.Errorf("failed to put asset private details: %v", err)
+	}
+
+	return "CreateAssetPrivate OK", nil
+}
+
+// ReadAssetPrivateDetails returns the private details of the asset with the
+// given id from the named private data collection.
+func (s *SmartContract) ReadAssetPrivateDetails(ctx contractapi.TransactionContextInterface, collection string, id string) (*AssetPrivateDetails, error) {
+	detailsJSON, err := ctx.GetStub().GetPrivateData(collection, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private details from collection %s: %v", collection, err)
+	}
+	if detailsJSON == nil {
+		return nil, fmt.Errorf("%w: no private details for asset %s in collection %s", ErrAssetNotFound, id, collection)
+	}
+
+	var details AssetPrivateDetails
+	if err := json.Unmarshal(detailsJSON, &details); err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// AgreeToTransfer records the price the calling org's client is willing to
+// transact at for a given asset. The price is taken from the transient map
+// (key "asset_value") and written only to the caller's own org collection,
+// so that it stays invisible to every other org until both sides agree.
+func (s *SmartContract) AgreeToTransfer(ctx contractapi.TransactionContextInterface) (string, error) {
+	transientMap, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return "", fmt.Errorf("failed to read transient map: %v", err)
+	}
+
+	transientJSON, ok := transientMap["asset_value"]
+	if !ok {
+		return "", fmt.Errorf("%w: asset_value key not found in the transient map", ErrInvalidInput)
+	}
+
+	var input AgreeToTransferRequest
+	if err := json.Unmarshal(transientJSON, &input); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(input); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(input.ID); err != nil {
+		return "", err
+	}
+
+	exists, err := s.AssetExists(ctx, input.ID)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", fmt.Errorf("%w: asset %s", ErrAssetNotFound, input.ID)
+	}
+
+	clientOrgID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("failed to get client MSP ID: %v", err)
+	}
+
+	agreement := AssetPrivateDetails{ID: input.ID, SalePrice: input.SalePrice}
+	agreementJSON, err := json.Marshal(agreement)
+	if err != nil {
+		return "", fmt.Errorf("failed json.Marshal: %v", err)
+	}
+
+	collection := privateDetailsCollection(clientOrgID)
+	if err := ctx.GetStub().PutPrivateData(collection, agreement.ID, agreementJSON); err != nil {
+		return "", fmt.Errorf("failed to put transfer agreement: %v", err)
+	}
+
+	return "AgreeToTransfer OK", nil
+}
+
+// TransferAssetPrivate transfers ownership of an asset once the calling
+// org and the buyer's org have independently agreed to the same price.
+// Agreement is checked with GetPrivateDataHash so that neither org's
+// agreed price is ever read by the other - only the hashes are compared.
+// The new owner and the buyer's MSP ID are supplied via the transient map
+// (key "asset_owner").
+func (s *SmartContract) TransferAssetPrivate(ctx contractapi.TransactionContextInterface) (string, error) {
+	transientMap, err := ctx.GetStub().GetTransient()
+	if err != nil {
+		return "", fmt.Errorf("failed to read transient map: %v", err)
+	}
+
+	transientJSON, ok := transientMap["asset_owner"]
+	if !ok {
+		return "", fmt.Errorf("%w: asset_owner key not found in the transient map", ErrInvalidInput)
+	}
+
+	var transferRequest TransferAssetPrivateRequest
+	if err := json.Unmarshal(transientJSON, &transferRequest); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(transferRequest); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(transferRequest.ID); err != nil {
+		return "", err
+	}
+
+	readRequest, err := json.Marshal(ReadAssetRequest{ID: transferRequest.ID})
+	if err != nil {
+		return "", fmt.Errorf("failed json.Marshal: %v", err)
+	}
+
+	asset, err := s.ReadAsset(ctx, string(readRequest))
+	if err != nil {
+		return "", err
+	}
+
+	if err := authorizeOwner(ctx, asset); err != nil {
+		return "", err
+	}
+
+	sellerMSP, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return "", fmt.Errorf("failed to get client MSP ID: %v", err)
+	}
+	if sellerMSP != asset.OwnerOrg {
+		return "", fmt.Errorf("%w: identity %s@%s is not the recorded owner org of asset %s", ErrUnauthorized, asset.Owner, sellerMSP, asset.ID)
+	}
+
+	sellerHash, err := ctx.GetStub().GetPrivateDataHash(privateDetailsCollection(sellerMSP), asset.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read seller price hash: %v", err)
+	}
+	if len(sellerHash) == 0 {
+		return "", fmt.Errorf("seller has not agreed to a price for asset %s", asset.ID)
+	}
+
+	buyerHash, err := ctx.GetStub().GetPrivateDataHash(privateDetailsCollection(transferRequest.BuyerMSP), asset.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read buyer price hash: %v", err)
+	}
+	if len(buyerHash) == 0 {
+		return "", fmt.Errorf("buyer has not agreed to a price for asset %s", asset.ID)
+	}
+
+	if string(sellerHash) != string(buyerHash) {
+		return "", fmt.Errorf("buyer and seller have not agreed to the same price for asset %s", asset.ID)
+	}
+
+	sellerDetails, err := s.ReadAssetPrivateDetails(ctx, privateDetailsCollection(sellerMSP), asset.ID)
+	if err != nil {
+		return "", err
+	}
+
+	asset.Owner = transferRequest.Owner
+	asset.OwnerOrg = transferRequest.BuyerMSP
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return "", fmt.Errorf("failed json.Marshal: %v", err)
+	}
+	if err := ctx.GetStub().PutState(asset.ID, assetJSON); err != nil {
+		return "", fmt.Errorf("failed to put asset to world state: %v", err)
+	}
+
+	details, err := s.ReadAssetPrivateDetails(ctx, assetCollection, asset.ID)
+	if err != nil {
+		return "", err
+	}
+	details.SalePrice = sellerDetails.SalePrice
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return "", fmt.Errorf("failed json.Marshal: %v", err)
+	}
+	if err := ctx.GetStub().PutPrivateData(assetCollection, asset.ID, detailsJSON); err != nil {
+		return "", fmt.Errorf("failed to update asset private details: %v", err)
+	}
+
+	if err := ctx.GetStub().DelPrivateData(privateDetailsCollection(sellerMSP), asset.ID); err != nil {
+		return "", fmt.Errorf("failed to clear seller price agreement: %v", err)
+	}
+	if err := ctx.GetStub().DelPrivateData(privateDetailsCollection(transferRequest.BuyerMSP), asset.ID); err != nil {
+		return "", fmt.Errorf("failed to clear buyer price agreement: %v", err)
+	}
+
+	return "TransferAssetPrivate OK", nil
+}
@@ -0,0 +1,49 @@
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/smartkjh/chaincode-example/chaincode/mocks"
+)
+
+func setTransient(stub *mocks.ChaincodeStub, key, value string) {
+	stub.Transient = map[string][]byte{key: []byte(value)}
+}
+
+func TestTransferAssetPrivateUpdatesOwnerAndOwnerOrg(t *testing.T) {
+	seller := newAuthedContext(t, "Org1MSP", "Tomoko", nil)
+
+	var contract SmartContract
+
+	setTransient(seller.Stub, "asset_properties", `{"asset_id":"asset1","color":"blue","size":5,"appraisedValue":300}`)
+	if _, err := contract.CreateAssetPrivate(seller); err != nil {
+		t.Fatalf("CreateAssetPrivate returned error: %v", err)
+	}
+
+	setTransient(seller.Stub, "asset_value", `{"asset_id":"asset1","salePrice":350}`)
+	if _, err := contract.AgreeToTransfer(seller); err != nil {
+		t.Fatalf("seller AgreeToTransfer returned error: %v", err)
+	}
+
+	buyer := &mocks.TransactionContext{
+		Stub:           seller.Stub,
+		ClientIdentity: &mocks.ClientIdentity{MSPID: "Org2MSP"},
+	}
+	setTransient(buyer.Stub, "asset_value", `{"asset_id":"asset1","salePrice":350}`)
+	if _, err := contract.AgreeToTransfer(buyer); err != nil {
+		t.Fatalf("buyer AgreeToTransfer returned error: %v", err)
+	}
+
+	setTransient(seller.Stub, "asset_owner", `{"asset_id":"asset1","owner":"Brad","buyerMSPID":"Org2MSP"}`)
+	if _, err := contract.TransferAssetPrivate(seller); err != nil {
+		t.Fatalf("TransferAssetPrivate returned error: %v", err)
+	}
+
+	asset, err := contract.ReadAsset(seller, `{"asset_id":"asset1"}`)
+	if err != nil {
+		t.Fatalf("ReadAsset returned error: %v", err)
+	}
+	if asset.Owner != "Brad" || asset.OwnerOrg != "Org2MSP" {
+		t.Fatalf("asset owner = %s@%s, want Brad@Org2MSP", asset.Owner, asset.OwnerOrg)
+	}
+}
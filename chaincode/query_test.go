@@ -0,0 +1,101 @@
+package chaincode
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+
+	"github.com/smartkjh/chaincode-example/chaincode/mocks"
+)
+
+func newAssetRecord(t *testing.T, asset Asset) *queryresult.KV {
+	t.Helper()
+
+	value, err := json.Marshal(asset)
+	if err != nil {
+		t.Fatalf("failed to marshal asset: %v", err)
+	}
+
+	return &queryresult.KV{Key: asset.ID, Value: value}
+}
+
+func TestQueryAssetsByOwner(t *testing.T) {
+	stub := mocks.NewChaincodeStub()
+	ctx := &mocks.TransactionContext{Stub: stub}
+
+	want := Asset{ID: "asset1", Color: "blue", Size: 5, Owner: "Tomoko", OwnerOrg: "Org1MSP", AppraisedValue: 300}
+	stub.QueryRecords = []*queryresult.KV{newAssetRecord(t, want)}
+
+	var contract SmartContract
+	got, err := contract.QueryAssetsByOwner(ctx, "Tomoko")
+	if err != nil {
+		t.Fatalf("QueryAssetsByOwner returned error: %v", err)
+	}
+	if len(got) != 1 || *got[0] != want {
+		t.Fatalf("QueryAssetsByOwner = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestQueryAssetsByColorSizeRange(t *testing.T) {
+	stub := mocks.NewChaincodeStub()
+	ctx := &mocks.TransactionContext{Stub: stub}
+
+	want := Asset{ID: "asset2", Color: "red", Size: 10, Owner: "Brad", OwnerOrg: "Org1MSP", AppraisedValue: 400}
+	stub.QueryRecords = []*queryresult.KV{newAssetRecord(t, want)}
+
+	var contract SmartContract
+	got, err := contract.QueryAssetsByColorSizeRange(ctx, "red", 5, 15)
+	if err != nil {
+		t.Fatalf("QueryAssetsByColorSizeRange returned error: %v", err)
+	}
+	if len(got) != 1 || *got[0] != want {
+		t.Fatalf("QueryAssetsByColorSizeRange = %+v, want [%+v]", got, want)
+	}
+}
+
+func TestQueryAssetsWithPagination(t *testing.T) {
+	stub := mocks.NewChaincodeStub()
+	ctx := &mocks.TransactionContext{Stub: stub}
+
+	want := Asset{ID: "asset3", Color: "green", Size: 10, Owner: "Jin Soo", OwnerOrg: "Org2MSP", AppraisedValue: 500}
+	stub.QueryRecords = []*queryresult.KV{newAssetRecord(t, want)}
+	stub.QueryBookmark = "next-page"
+
+	var contract SmartContract
+	result, err := contract.QueryAssetsWithPagination(ctx, `{"selector":{}}`, 10, "")
+	if err != nil {
+		t.Fatalf("QueryAssetsWithPagination returned error: %v", err)
+	}
+	if len(result.Records) != 1 || *result.Records[0] != want {
+		t.Fatalf("QueryAssetsWithPagination.Records = %+v, want [%+v]", result.Records, want)
+	}
+	if result.Bookmark != "next-page" {
+		t.Fatalf("QueryAssetsWithPagination.Bookmark = %q, want %q", result.Bookmark, "next-page")
+	}
+	if result.FetchedRecordsCount != 1 {
+		t.Fatalf("QueryAssetsWithPagination.FetchedRecordsCount = %d, want 1", result.FetchedRecordsCount)
+	}
+}
+
+func TestQueryAssetsByOwnerEscapesSelectorValue(t *testing.T) {
+	stub := mocks.NewChaincodeStub()
+	ctx := &mocks.TransactionContext{Stub: stub}
+
+	owner := `"},"$or":[{"owner":"x`
+
+	var contract SmartContract
+	if _, err := contract.QueryAssetsByOwner(ctx, owner); err != nil {
+		t.Fatalf("QueryAssetsByOwner returned error: %v", err)
+	}
+
+	var query struct {
+		Selector map[string]interface{} `json:"selector"`
+	}
+	if err := json.Unmarshal([]byte(stub.LastQuery), &query); err != nil {
+		t.Fatalf("query %q is not valid JSON: %v", stub.LastQuery, err)
+	}
+	if len(query.Selector) != 1 || query.Selector["owner"] != owner {
+		t.Fatalf("selector = %v, want only owner=%q", query.Selector, owner)
+	}
+}
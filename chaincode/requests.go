@@ -0,0 +1,103 @@
+package chaincode
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// assetIDPattern constrains asset IDs to a safe, predictable key space.
+var assetIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+var validate = validator.New()
+
+// CreateAssetRequest is the validated input to CreateAsset.
+type CreateAssetRequest struct {
+	ID             string `json:"asset_id" validate:"required"`
+	Color          string `json:"color" validate:"required"`
+	Size           int    `json:"size" validate:"gte=0"`
+	AppraisedValue int    `json:"appraisedValue" validate:"gte=0"`
+}
+
+// ReadAssetRequest is the validated input to ReadAsset.
+type ReadAssetRequest struct {
+	ID string `json:"asset_id" validate:"required"`
+}
+
+// UpdateAssetRequest is the validated input to UpdateAsset. It deliberately
+// has no Owner/OwnerOrg fields - reassigning ownership only happens through
+// TransferAsset, which enforces the ownership check.
+type UpdateAssetRequest struct {
+	ID             string `json:"asset_id" validate:"required"`
+	Color          string `json:"color" validate:"required"`
+	Size           int    `json:"size" validate:"gte=0"`
+	AppraisedValue int    `json:"appraisedValue" validate:"gte=0"`
+}
+
+// DeleteAssetRequest is the validated input to DeleteAsset.
+type DeleteAssetRequest struct {
+	ID string `json:"asset_id" validate:"required"`
+}
+
+// TransferAssetRequest is the validated input to TransferAsset.
+type TransferAssetRequest struct {
+	ID       string `json:"asset_id" validate:"required"`
+	Owner    string `json:"owner" validate:"required"`
+	OwnerOrg string `json:"ownerOrg" validate:"required"`
+}
+
+// AssetHistoryRequest is the validated input to GetAssetHistory.
+type AssetHistoryRequest struct {
+	ID string `json:"asset_id" validate:"required"`
+}
+
+// AssetAtTimeRequest is the validated input to GetAssetAtTime.
+type AssetAtTimeRequest struct {
+	ID        string `json:"asset_id" validate:"required"`
+	Timestamp string `json:"timestamp" validate:"required"`
+}
+
+// CreateAssetPrivateRequest is the validated transient input to
+// CreateAssetPrivate.
+type CreateAssetPrivateRequest struct {
+	ID             string `json:"asset_id" validate:"required"`
+	Color          string `json:"color" validate:"required"`
+	Size           int    `json:"size" validate:"gte=0"`
+	AppraisedValue int    `json:"appraisedValue" validate:"gte=0"`
+}
+
+// AgreeToTransferRequest is the validated transient input to
+// AgreeToTransfer.
+type AgreeToTransferRequest struct {
+	ID        string `json:"asset_id" validate:"required"`
+	SalePrice int    `json:"salePrice" validate:"gte=0"`
+}
+
+// TransferAssetPrivateRequest is the validated transient input to
+// TransferAssetPrivate.
+type TransferAssetPrivateRequest struct {
+	ID       string `json:"asset_id" validate:"required"`
+	Owner    string `json:"owner" validate:"required"`
+	BuyerMSP string `json:"buyerMSPID" validate:"required"`
+}
+
+// validateRequest runs req through the struct validator, wrapping any
+// failure as ErrInvalidInput.
+func validateRequest(req interface{}) error {
+	if err := validate.Struct(req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+
+	return nil
+}
+
+// validateAssetID rejects IDs that don't match assetIDPattern, wrapping
+// the failure as ErrInvalidInput.
+func validateAssetID(id string) error {
+	if !assetIDPattern.MatchString(id) {
+		return fmt.Errorf("%w: asset id %q must match %s", ErrInvalidInput, id, assetIDPattern.String())
+	}
+
+	return nil
+}
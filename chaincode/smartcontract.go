@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+	"github.com/smartkjh/chaincode-example/chaincode/events"
 )
 
 // SmartContract provides functions for managing an Asset
@@ -18,18 +21,33 @@ type Asset struct {
 	Color          string `json:"color"`
 	Size           int    `json:"size"`
 	Owner          string `json:"owner"`
+	OwnerOrg       string `json:"ownerOrg"`
 	AppraisedValue int    `json:"appraisedValue"`
 }
 
-// InitLedger adds a base set of assets to the ledger
+// InitLedger adds a base set of assets to the ledger. It is an admin-only
+// operation since it seeds data outside of the normal client-identity
+// ownership flow. Seeded assets are recorded under the submitting admin's
+// MSP ID so that authorizeOwner's mspID check has a real org to match
+// against, rather than leaving OwnerOrg at its zero value and permanently
+// locking every seeded asset out of the ownership path.
 func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	mspID, err := ctx.GetClientIdentity().GetMSPID()
+	if err != nil {
+		return fmt.Errorf("failed to get client MSP ID: %v", err)
+	}
+
 	assets := []Asset{
-		{ID: "asset1", Color: "blue", Size: 5, Owner: "Tomoko", AppraisedValue: 300},
-		{ID: "asset2", Color: "red", Size: 5, Owner: "Brad", AppraisedValue: 400},
-		{ID: "asset3", Color: "green", Size: 10, Owner: "Jin Soo", AppraisedValue: 500},
-		{ID: "asset4", Color: "yellow", Size: 10, Owner: "Max", AppraisedValue: 600},
-		{ID: "asset5", Color: "black", Size: 15, Owner: "Adriana", AppraisedValue: 700},
-		{ID: "asset6", Color: "white", Size: 15, Owner: "Michel", AppraisedValue: 800},
+		{ID: "asset1", Color: "blue", Size: 5, Owner: "Tomoko", OwnerOrg: mspID, AppraisedValue: 300},
+		{ID: "asset2", Color: "red", Size: 5, Owner: "Brad", OwnerOrg: mspID, AppraisedValue: 400},
+		{ID: "asset3", Color: "green", Size: 10, Owner: "Jin Soo", OwnerOrg: mspID, AppraisedValue: 500},
+		{ID: "asset4", Color: "yellow", Size: 10, Owner: "Max", OwnerOrg: mspID, AppraisedValue: 600},
+		{ID: "asset5", Color: "black", Size: 15, Owner: "Adriana", OwnerOrg: mspID, AppraisedValue: 700},
+		{ID: "asset6", Color: "white", Size: 15, Owner: "Michel", OwnerOrg: mspID, AppraisedValue: 800},
 	}
 
 	for _, asset := range assets {
@@ -49,10 +67,14 @@ func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface)
 
 // CreateAsset issues a new asset to the world state with given details.
 func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface, rawAssetEnrollRequest string) (string, error) {
-
-	var assetEnrollRequest Asset
-	err := json.Unmarshal([]byte(rawAssetEnrollRequest), &assetEnrollRequest)
-	if err != nil {
+	var assetEnrollRequest CreateAssetRequest
+	if err := json.Unmarshal([]byte(rawAssetEnrollRequest), &assetEnrollRequest); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(assetEnrollRequest); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(assetEnrollRequest.ID); err != nil {
 		return "", err
 	}
 
@@ -61,27 +83,47 @@ func (s *SmartContract) CreateAsset(ctx contractapi.TransactionContextInterface,
 		return "", err
 	}
 	if exists {
-		return "", fmt.Errorf("the asset %s already exists", assetEnrollRequest.ID)
+		return "", fmt.Errorf("%w: asset %s", ErrAssetExists, assetEnrollRequest.ID)
+	}
+
+	cn, mspID, err := getSubmittingClientIdentity(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	asset := Asset{
+		ID:             assetEnrollRequest.ID,
+		Color:          assetEnrollRequest.Color,
+		Size:           assetEnrollRequest.Size,
+		Owner:          cn,
+		OwnerOrg:       mspID,
+		AppraisedValue: assetEnrollRequest.AppraisedValue,
 	}
 
-	result, err := json.Marshal(assetEnrollRequest)
+	result, err := json.Marshal(asset)
 	if err != nil {
 		return "", fmt.Errorf("failed json.Marshal: %v", err)
 	}
 
-	err = ctx.GetStub().PutState(assetEnrollRequest.ID, result)
+	err = ctx.GetStub().PutState(asset.ID, result)
 	if err != nil {
 		return "", err
 	}
 
+	if err := setAssetEvent(ctx, events.AssetCreated, events.AssetCreatedPayload{ID: asset.ID}); err != nil {
+		return "", err
+	}
+
 	return "CreateAsset OK", nil
 }
 
 // ReadAsset returns the asset stored in the world state with given id.
 func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, rawAssetReadRequest string) (*Asset, error) {
-	var assetReadRequest Asset
-	err := json.Unmarshal([]byte(rawAssetReadRequest), &assetReadRequest)
-	if err != nil {
+	var assetReadRequest ReadAssetRequest
+	if err := json.Unmarshal([]byte(rawAssetReadRequest), &assetReadRequest); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(assetReadRequest); err != nil {
 		return nil, err
 	}
 
@@ -90,7 +132,7 @@ func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, r
 		return nil, fmt.Errorf("failed to read from world state: %v", err)
 	}
 	if assetJSON == nil {
-		return nil, fmt.Errorf("the asset %s does not exist", assetReadRequest.ID)
+		return nil, fmt.Errorf("%w: asset %s", ErrAssetNotFound, assetReadRequest.ID)
 	}
 
 	var asset Asset
@@ -102,41 +144,72 @@ func (s *SmartContract) ReadAsset(ctx contractapi.TransactionContextInterface, r
 	return &asset, nil
 }
 
-// UpdateAsset updates an existing asset in the world state with provided parameters.
+// UpdateAsset updates an existing asset in the world state with provided
+// parameters. The caller must either be the current owner or hold the
+// asset.transfer attribute; ownership itself cannot be changed here, only
+// through TransferAsset.
 func (s *SmartContract) UpdateAsset(ctx contractapi.TransactionContextInterface, rawAssetUpdateRequest string) (string, error) {
+	var assetUpdateRequest UpdateAssetRequest
+	if err := json.Unmarshal([]byte(rawAssetUpdateRequest), &assetUpdateRequest); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(assetUpdateRequest); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(assetUpdateRequest.ID); err != nil {
+		return "", err
+	}
 
-	var assetUpdateRequest Asset
-	err := json.Unmarshal([]byte(rawAssetUpdateRequest), &assetUpdateRequest)
+	existingAsset, err := s.ReadAsset(ctx, rawAssetUpdateRequest)
 	if err != nil {
 		return "", err
 	}
 
-	exists, err := s.AssetExists(ctx, assetUpdateRequest.ID)
-	if err != nil {
+	if err := authorizeOwner(ctx, existingAsset); err != nil {
 		return "", err
 	}
-	if !exists {
-		return "", fmt.Errorf("the asset %s does not exist", assetUpdateRequest.ID)
+
+	asset := Asset{
+		ID:             assetUpdateRequest.ID,
+		Color:          assetUpdateRequest.Color,
+		Size:           assetUpdateRequest.Size,
+		Owner:          existingAsset.Owner,
+		OwnerOrg:       existingAsset.OwnerOrg,
+		AppraisedValue: assetUpdateRequest.AppraisedValue,
 	}
 
-	result, err := json.Marshal(assetUpdateRequest)
+	result, err := json.Marshal(asset)
 	if err != nil {
 		return "", fmt.Errorf("failed json.Marshal: %v", err)
 	}
 
-	err = ctx.GetStub().PutState(assetUpdateRequest.ID, result)
+	err = ctx.GetStub().PutState(asset.ID, result)
 	if err != nil {
 		return "", err
 	}
 
+	if err := setAssetEvent(ctx, events.AssetUpdated, events.AssetUpdatedPayload{ID: assetUpdateRequest.ID}); err != nil {
+		return "", err
+	}
+
 	return "UpdateAsset OK", nil
 }
 
-// DeleteAsset deletes an given asset from the world state.
+// DeleteAsset deletes an given asset from the world state. It is an
+// admin-only operation.
 func (s *SmartContract) DeleteAsset(ctx contractapi.TransactionContextInterface, rawAssetDeleteRequest string) (string, error) {
-	var assetDeleteRequest Asset
-	err := json.Unmarshal([]byte(rawAssetDeleteRequest), &assetDeleteRequest)
-	if err != nil {
+	if err := authorizeAdmin(ctx); err != nil {
+		return "", err
+	}
+
+	var assetDeleteRequest DeleteAssetRequest
+	if err := json.Unmarshal([]byte(rawAssetDeleteRequest), &assetDeleteRequest); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(assetDeleteRequest); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(assetDeleteRequest.ID); err != nil {
 		return "", err
 	}
 
@@ -145,7 +218,7 @@ func (s *SmartContract) DeleteAsset(ctx contractapi.TransactionContextInterface,
 		return "", err
 	}
 	if !exists {
-		return "", fmt.Errorf("the asset %s does not exist", assetDeleteRequest.ID)
+		return "", fmt.Errorf("%w: asset %s", ErrAssetNotFound, assetDeleteRequest.ID)
 	}
 
 	err = ctx.GetStub().DelState(assetDeleteRequest.ID)
@@ -153,6 +226,10 @@ func (s *SmartContract) DeleteAsset(ctx contractapi.TransactionContextInterface,
 		return "", err
 	}
 
+	if err := setAssetEvent(ctx, events.AssetDeleted, events.AssetDeletedPayload{ID: assetDeleteRequest.ID}); err != nil {
+		return "", err
+	}
+
 	return "CreateAsset OK", nil
 }
 
@@ -166,19 +243,27 @@ func (s *SmartContract) AssetExists(ctx contractapi.TransactionContextInterface,
 	return assetJSON != nil, nil
 }
 
-// TransferAsset updates the owner field of asset with given id in world state.
+// TransferAsset updates the owner field of asset with given id in world
+// state. The caller must either be the current owner or hold the
+// asset.transfer attribute.
 func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterface, rawAssetTransferRequest string) (string, error) {
-	var assetTransferRequest Asset
-	err := json.Unmarshal([]byte(rawAssetTransferRequest), &assetTransferRequest)
-	if err != nil {
+	var assetTransferRequest TransferAssetRequest
+	if err := json.Unmarshal([]byte(rawAssetTransferRequest), &assetTransferRequest); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidInput, err)
+	}
+	if err := validateRequest(assetTransferRequest); err != nil {
+		return "", err
+	}
+	if err := validateAssetID(assetTransferRequest.ID); err != nil {
 		return "", err
 	}
+
 	exists, err := s.AssetExists(ctx, assetTransferRequest.ID)
 	if err != nil {
 		return "", err
 	}
 	if !exists {
-		return "", fmt.Errorf("the asset %s does not exist", assetTransferRequest.ID)
+		return "", fmt.Errorf("%w: asset %s", ErrAssetNotFound, assetTransferRequest.ID)
 	}
 
 	asset, err := s.ReadAsset(ctx, rawAssetTransferRequest)
@@ -186,7 +271,13 @@ func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterfac
 		return "", err
 	}
 
+	if err := authorizeOwner(ctx, asset); err != nil {
+		return "", err
+	}
+
+	previousOwner := asset.Owner
 	asset.Owner = assetTransferRequest.Owner
+	asset.OwnerOrg = assetTransferRequest.OwnerOrg
 	result, err := json.Marshal(asset)
 	if err != nil {
 		return "", fmt.Errorf("failed json.Marshal: %v", err)
@@ -197,6 +288,11 @@ func (s *SmartContract) TransferAsset(ctx contractapi.TransactionContextInterfac
 		return "", err
 	}
 
+	payload := events.AssetTransferredPayload{ID: asset.ID, From: previousOwner, To: asset.Owner}
+	if err := setAssetEvent(ctx, events.AssetTransferred, payload); err != nil {
+		return "", err
+	}
+
 	return "TransferAsset OK", nil
 }
 
@@ -227,3 +323,112 @@ func (s *SmartContract) GetAllAssets(ctx contractapi.TransactionContextInterface
 
 	return assets, nil
 }
+
+// setAssetEvent marshals payload and sets it on the transaction under name,
+// so that SDK clients can subscribe to asset lifecycle changes.
+func setAssetEvent(ctx contractapi.TransactionContextInterface, name string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed json.Marshal: %v", err)
+	}
+
+	if err := ctx.GetStub().SetEvent(name, payloadJSON); err != nil {
+		return fmt.Errorf("failed to set event %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// PaginatedQueryResult wraps a page of assets together with the CouchDB
+// bookmark needed to fetch the next page and the number of records the
+// peer examined to build this page.
+type PaginatedQueryResult struct {
+	Records             []*Asset `json:"records"`
+	FetchedRecordsCount int32    `json:"fetchedRecordsCount"`
+	Bookmark            string   `json:"bookmark"`
+}
+
+// QueryAssets runs an arbitrary CouchDB Mongo-style selector query against
+// the world state and returns every matching asset. rawSelector must be a
+// JSON object in the form {"selector":{...}}.
+func (s *SmartContract) QueryAssets(ctx contractapi.TransactionContextInterface, rawSelector string) ([]*Asset, error) {
+	resultsIterator, err := ctx.GetStub().GetQueryResult(rawSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	return assetsFromIterator(resultsIterator)
+}
+
+// QueryAssetsByOwner returns all assets currently held by the given owner.
+// It is backed by the indexOwner CouchDB index.
+func (s *SmartContract) QueryAssetsByOwner(ctx contractapi.TransactionContextInterface, owner string) ([]*Asset, error) {
+	selector, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{"owner": owner},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed json.Marshal: %v", err)
+	}
+
+	return s.QueryAssets(ctx, string(selector))
+}
+
+// QueryAssetsByColorSizeRange returns all assets of the given color whose
+// size falls within [minSize, maxSize]. It is backed by the indexColorSize
+// CouchDB index.
+func (s *SmartContract) QueryAssetsByColorSizeRange(ctx contractapi.TransactionContextInterface, color string, minSize int, maxSize int) ([]*Asset, error) {
+	selector, err := json.Marshal(map[string]interface{}{
+		"selector": map[string]interface{}{
+			"color": color,
+			"size":  map[string]interface{}{"$gte": minSize, "$lte": maxSize},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed json.Marshal: %v", err)
+	}
+
+	return s.QueryAssets(ctx, string(selector))
+}
+
+// QueryAssetsWithPagination runs a CouchDB Mongo-style selector query with
+// pageSize and bookmark arguments, returning a PaginatedQueryResult that
+// clients can use to page through large result sets.
+func (s *SmartContract) QueryAssetsWithPagination(ctx contractapi.TransactionContextInterface, rawSelector string, pageSize int32, bookmark string) (*PaginatedQueryResult, error) {
+	resultsIterator, responseMetadata, err := ctx.GetStub().GetQueryResultWithPagination(rawSelector, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute paginated query: %v", err)
+	}
+	defer resultsIterator.Close()
+
+	assets, err := assetsFromIterator(resultsIterator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedQueryResult{
+		Records:             assets,
+		FetchedRecordsCount: responseMetadata.FetchedRecordsCount,
+		Bookmark:            responseMetadata.Bookmark,
+	}, nil
+}
+
+// assetsFromIterator drains a state query iterator into a slice of assets.
+func assetsFromIterator(resultsIterator shim.StateQueryIteratorInterface) ([]*Asset, error) {
+	var assets []*Asset
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var asset Asset
+		err = json.Unmarshal(queryResponse.Value, &asset)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, &asset)
+	}
+
+	return assets, nil
+}